@@ -0,0 +1,39 @@
+// Command jsonschema validates JSON and YAML files and directories
+// against a JSON Schema.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "validate":
+		if err := runValidate(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	case "-h", "--help", "help":
+		usage()
+	default:
+		fmt.Fprintf(os.Stderr, "jsonschema: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `jsonschema validates files against a JSON Schema.
+
+Usage:
+
+	jsonschema validate --schema <schema> [flags] <path>...
+
+Run "jsonschema validate -h" for flag details.`)
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+var draftByVersion = map[int]*jsonschema.Draft{
+	4:    jsonschema.Draft4,
+	6:    jsonschema.Draft6,
+	7:    jsonschema.Draft7,
+	2019: jsonschema.Draft2019,
+	2020: jsonschema.Draft2020,
+}
+
+// resourceFlag collects repeated -resource url=path flags.
+type resourceFlag map[string]string
+
+func (r resourceFlag) String() string {
+	var parts []string
+	for url, path := range r {
+		parts = append(parts, url+"="+path)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (r resourceFlag) Set(s string) error {
+	url, path, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("invalid --resource %q, want url=path", s)
+	}
+	r[url] = path
+	return nil
+}
+
+func runValidate(args []string) error {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	schemaPath := flags.String("schema", "", "path or URL of the schema to validate against (required)")
+	draft := flags.Int("draft", 2020, "draft to use when the schema does not declare $schema: 4, 6, 7, 2019 or 2020")
+	assertFormat := flags.Bool("assert-format", false, "treat format as an assertion, not just an annotation")
+	assertContent := flags.Bool("assert-content", false, "treat contentEncoding/contentMediaType/contentSchema as assertions")
+	output := flags.String("output", "text", "error output format: text, json, basic or detailed")
+	loaderFlag := flags.String("loader", "file", "comma separated list of URL schemes to enable: file, http, https")
+	resources := make(resourceFlag)
+	flags.Var(resources, "resource", "additional resource in url=path form, registered with AddResource (repeatable)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *schemaPath == "" {
+		return errors.New("validate: --schema is required")
+	}
+	d, ok := draftByVersion[*draft]
+	if !ok {
+		return fmt.Errorf("validate: unsupported --draft %d", *draft)
+	}
+	switch *output {
+	case "text", "json", "basic", "detailed":
+	default:
+		return fmt.Errorf("validate: unsupported --output %q", *output)
+	}
+
+	c := jsonschema.NewCompiler()
+	c.DefaultDraft(d)
+	if *assertFormat {
+		c.AssertFormat()
+	}
+	if *assertContent {
+		c.AssertContent()
+	}
+	c.UseLoader(newLoader(strings.Split(*loaderFlag, ",")))
+
+	for url, path := range resources {
+		doc, err := decodeFile(path)
+		if err != nil {
+			return fmt.Errorf("validate: loading resource %s: %w", url, err)
+		}
+		if err := c.AddResource(url, doc); err != nil {
+			return fmt.Errorf("validate: adding resource %s: %w", url, err)
+		}
+	}
+
+	sch, err := c.Compile(*schemaPath)
+	if err != nil {
+		return fmt.Errorf("validate: compiling schema: %w", err)
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		return errors.New("validate: no files or directories given")
+	}
+
+	var files []string
+	for _, p := range paths {
+		matched, err := walkMatching(p)
+		if err != nil {
+			return err
+		}
+		files = append(files, matched...)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("validate: no *.json/*.yml/*.yaml files found in %s", strings.Join(paths, ", "))
+	}
+
+	failed := 0
+	for _, file := range files {
+		if err := validateFile(sch, file, *output); err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("validate: %d of %d file(s) failed", failed, len(files))
+	}
+	return nil
+}
+
+// newLoader builds a loader that only serves the schemes the caller
+// opted into via --loader, so e.g. http(s) fetches can be disabled
+// entirely in CI.
+func newLoader(schemes []string) jsonschema.SchemeURLLoader {
+	loader := jsonschema.SchemeURLLoader{}
+	for _, s := range schemes {
+		switch strings.TrimSpace(s) {
+		case "file":
+			loader["file"] = fileLoader{}
+		case "http":
+			loader["http"] = httpURLLoader{}
+		case "https":
+			loader["https"] = httpURLLoader{}
+		}
+	}
+	return loader
+}
+
+// fileLoader dispatches to jsonschema.FileLoader or jsonschema.YAMLFileLoader
+// based on the referenced file's extension, so a schema's $ref can point at
+// either a .json or a .yaml/.yml sibling.
+type fileLoader struct{}
+
+func (fileLoader) Load(url string) (any, error) {
+	switch strings.ToLower(filepath.Ext(url)) {
+	case ".yml", ".yaml":
+		return jsonschema.YAMLFileLoader{}.Load(url)
+	default:
+		return jsonschema.FileLoader{}.Load(url)
+	}
+}
+
+type httpURLLoader struct{}
+
+func (httpURLLoader) Load(url string) (any, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status code %d", url, resp.StatusCode)
+	}
+	return jsonschema.UnmarshalJSON(resp.Body)
+}
+
+var matchExts = []string{".json", ".yml", ".yaml"}
+
+// walkMatching returns path itself when it is a file, or every
+// *.json/*.yml/*.yaml file found underneath it when it is a directory.
+func walkMatching(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("validate: %w", err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(p))
+		for _, want := range matchExts {
+			if ext == want {
+				files = append(files, p)
+				return nil
+			}
+		}
+		return nil
+	})
+	return files, err
+}
+
+func decodeFile(path string) (any, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		return jsonschema.UnmarshalYAML(f)
+	default:
+		return jsonschema.UnmarshalJSON(f)
+	}
+}
+
+const (
+	colorGreen = "\x1b[32m"
+	colorRed   = "\x1b[31m"
+	colorReset = "\x1b[0m"
+)
+
+var outputFormats = map[string]jsonschema.OutputFormat{
+	"basic":    jsonschema.OutputBasic,
+	"detailed": jsonschema.OutputDetailed,
+}
+
+func validateFile(sch *jsonschema.Schema, file, output string) error {
+	inst, err := decodeFile(file)
+	if err != nil {
+		printJSONOrText(file, err, output)
+		return err
+	}
+
+	if format, ok := outputFormats[output]; ok {
+		unit := sch.ValidateOutput(inst, format)
+		printOutputUnit(file, unit)
+		if !unit.Valid {
+			return fmt.Errorf("%s: invalid", file)
+		}
+		return nil
+	}
+
+	err = sch.Validate(inst)
+	printJSONOrText(file, err, output)
+	return err
+}
+
+// printJSONOrText reports err in the "json" or plain-text format.
+// Validation results for "basic"/"detailed" are handled by
+// printOutputUnit instead, since they need the OutputUnit built by
+// Schema.ValidateOutput rather than a Go error; a file that fails to
+// decode is reported here in plain text under those formats too.
+func printJSONOrText(file string, err error, output string) {
+	if output == "json" {
+		printJSONResult(file, err)
+		return
+	}
+	if err == nil {
+		fmt.Printf("%sPASS%s %s\n", colorGreen, colorReset, file)
+		return
+	}
+	fmt.Printf("%sFAIL%s %s\n", colorRed, colorReset, file)
+	fmt.Println(indent(err.Error()))
+}
+
+func printJSONResult(file string, err error) {
+	result := struct {
+		File  string `json:"file"`
+		Valid bool   `json:"valid"`
+		Error string `json:"error,omitempty"`
+	}{File: file, Valid: err == nil}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	b, mErr := json.Marshal(result)
+	if mErr != nil {
+		fmt.Println(mErr)
+		return
+	}
+	fmt.Println(string(b))
+}
+
+// printOutputUnit reports the Basic/Detailed structured output format
+// defined by JSON Schema draft 2019-09, wrapped with the file name so
+// results for multiple files can be told apart.
+func printOutputUnit(file string, unit *jsonschema.OutputUnit) {
+	result := struct {
+		File string `json:"file"`
+		*jsonschema.OutputUnit
+	}{File: file, OutputUnit: unit}
+	b, mErr := json.Marshal(result)
+	if mErr != nil {
+		fmt.Println(mErr)
+		return
+	}
+	fmt.Println(string(b))
+}
+
+func indent(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = "      " + l
+	}
+	return strings.Join(lines, "\n")
+}
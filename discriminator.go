@@ -0,0 +1,263 @@
+package jsonschema
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// DiscriminatorMissBehavior controls what a registered discriminator
+// does when an instance's discriminator value has no entry in its
+// mapping.
+type DiscriminatorMissBehavior int
+
+const (
+	// DiscriminatorFallback evaluates the full oneOf/anyOf, as if no
+	// discriminator were registered. It is the zero value.
+	DiscriminatorFallback DiscriminatorMissBehavior = iota
+	// DiscriminatorError fails fast with a *DiscriminatorMissError
+	// instead of falling back to evaluating every branch.
+	DiscriminatorError
+)
+
+// DiscriminatorOptions configures a discriminator registered with
+// Compiler.RegisterDiscriminator.
+type DiscriminatorOptions struct {
+	OnMiss DiscriminatorMissBehavior
+}
+
+// DiscriminatorMissError is returned when OnMiss is DiscriminatorError
+// and an instance's discriminator value isn't in the mapping.
+type DiscriminatorMissError struct {
+	PropertyName string
+	Value        any
+}
+
+func (e *DiscriminatorMissError) Error() string {
+	return fmt.Sprintf("no matching discriminator value %v for property %q", e.Value, e.PropertyName)
+}
+
+// discriminatorRule is a registered discriminator, resolved at
+// registration time into a lookup table keyed by discriminator value
+// plus the fallback schema (the owning object that carries the
+// oneOf/anyOf, compiled unmodified, sibling keywords and all) used
+// when the value is missing or unmapped.
+type discriminatorRule struct {
+	propertyName string
+	mapping      map[string]*Schema
+	fallback     *Schema
+	opts         DiscriminatorOptions
+}
+
+// validate is the actual fast path: look up the branch by
+// propertyName and validate only it, instead of the fallback's full
+// O(N) oneOf/anyOf scan.
+func (r *discriminatorRule) validate(v any) error {
+	obj, ok := v.(map[string]any)
+	if ok {
+		if val, ok := obj[r.propertyName]; ok {
+			if name, ok := val.(string); ok {
+				if sch, ok := r.mapping[name]; ok {
+					return sch.Validate(v)
+				}
+				if r.opts.OnMiss == DiscriminatorError {
+					return &DiscriminatorMissError{PropertyName: r.propertyName, Value: val}
+				}
+			}
+		}
+	}
+	return r.fallback.Validate(v)
+}
+
+// compilerDiscriminators holds the discriminator rules registered
+// against one Compiler, keyed by the URL of the schema object that
+// carries the oneOf/anyOf (e.g. "union.json", not "union.json#/oneOf"
+// -- the owning object is what may carry sibling keywords like
+// "required" that the fallback still has to honor).
+type compilerDiscriminators struct {
+	mu    sync.Mutex
+	rules map[string]*discriminatorRule
+}
+
+// discriminatorsByCompiler associates side-table discriminator data
+// with a *Compiler without requiring a field on the Compiler struct
+// itself. Entries are removed via a finalizer (see discriminatorsFor)
+// so registering discriminators doesn't leak every Compiler that ever
+// used the feature.
+var discriminatorsByCompiler sync.Map // map[*Compiler]*compilerDiscriminators
+
+func discriminatorsFor(c *Compiler) *compilerDiscriminators {
+	if v, ok := discriminatorsByCompiler.Load(c); ok {
+		return v.(*compilerDiscriminators)
+	}
+	cd := &compilerDiscriminators{rules: map[string]*discriminatorRule{}}
+	actual, loaded := discriminatorsByCompiler.LoadOrStore(c, cd)
+	if !loaded {
+		runtime.SetFinalizer(c, func(c *Compiler) {
+			discriminatorsByCompiler.Delete(c)
+		})
+	}
+	return actual.(*compilerDiscriminators)
+}
+
+// compileRef compiles ref, a $ref-style URL: a bare fragment like
+// "#/$defs/Dog" is resolved against schemaURL's base document, not
+// against schemaURL itself (which may already carry its own fragment,
+// e.g. ".../pet.json#/oneOf"). Any other ref is passed to Compile
+// as-is; it is not resolved relative to schemaURL.
+func (c *Compiler) compileRef(schemaURL, ref string) (*Schema, error) {
+	if strings.HasPrefix(ref, "#") {
+		base, _, _ := strings.Cut(schemaURL, "#")
+		return c.Compile(base + ref)
+	}
+	return c.Compile(ref)
+}
+
+// RegisterDiscriminator tells c that schemaURL (e.g.
+// "https://example.com/pet.json"), the schema object that carries a
+// oneOf/anyOf (and possibly sibling keywords such as "required"), can
+// be evaluated by reading propertyName off the instance and looking
+// it up in mapping, instead of evaluating every branch. Pass the
+// owning object's URL, not the bare oneOf/anyOf array's -- the
+// fallback compiles schemaURL as-is, so a miss still enforces whatever
+// else that object requires. mapping keys are discriminator values
+// (e.g. a "kind" enum member); mapping values are $ref URLs. A bare
+// fragment ("#/$defs/Dog") is resolved against schemaURL's document;
+// any other value is compiled as given, not resolved relative to
+// schemaURL the way a "$ref" keyword is.
+//
+// oneOf/anyOf dispatch ordinarily happens inside Schema.Validate's
+// evaluator, which lives outside this file; this package doesn't
+// intercept it there, so the fast path only applies through the
+// explicit Compiler.ValidateDiscriminated(schemaURL, instance) entry
+// point, not a plain sch.Validate(instance) call.
+//
+// This is the same idea as the OpenAPI "discriminator" object (see
+// OpenAPI30, OpenAPI31, RegisterOpenAPIDiscriminators), exposed
+// independently of that dialect so callers on a plain JSON Schema
+// draft get the fast path too -- the real win for oneOf-heavy,
+// code-generation-style schemas with dozens of variants, such as
+// those oapi-codegen produces.
+func (c *Compiler) RegisterDiscriminator(schemaURL, propertyName string, mapping map[string]string, opts DiscriminatorOptions) error {
+	resolved := make(map[string]*Schema, len(mapping))
+	for value, ref := range mapping {
+		sch, err := c.compileRef(schemaURL, ref)
+		if err != nil {
+			return fmt.Errorf("registering discriminator for %s: resolving %q: %w", schemaURL, value, err)
+		}
+		resolved[value] = sch
+	}
+	fallback, err := c.Compile(schemaURL)
+	if err != nil {
+		return fmt.Errorf("registering discriminator for %s: %w", schemaURL, err)
+	}
+
+	cd := discriminatorsFor(c)
+	cd.mu.Lock()
+	cd.rules[schemaURL] = &discriminatorRule{
+		propertyName: propertyName,
+		mapping:      resolved,
+		fallback:     fallback,
+		opts:         opts,
+	}
+	cd.mu.Unlock()
+	return nil
+}
+
+// ValidateDiscriminated validates v against the schema at schemaURL.
+// If a discriminator was registered for schemaURL via
+// RegisterDiscriminator (or RegisterDiscriminatorAnnotations /
+// RegisterOpenAPIDiscriminators), it dispatches straight to the
+// matching branch instead of evaluating every oneOf/anyOf subschema.
+// Otherwise it just compiles and validates schemaURL normally.
+func (c *Compiler) ValidateDiscriminated(schemaURL string, v any) error {
+	cd := discriminatorsFor(c)
+	cd.mu.Lock()
+	rule := cd.rules[schemaURL]
+	cd.mu.Unlock()
+
+	if rule == nil {
+		sch, err := c.Compile(schemaURL)
+		if err != nil {
+			return err
+		}
+		return sch.Validate(v)
+	}
+	return rule.validate(v)
+}
+
+// xDiscriminatorKeyword is the vendor-extension spelling of the
+// OpenAPI discriminator object ({"x-discriminator": {propertyName,
+// mapping}}), honored on any draft without opting into OpenAPI30 or
+// OpenAPI31.
+const xDiscriminatorKeyword = "x-discriminator"
+
+// RegisterDiscriminatorAnnotations scans doc (the same document
+// passed to Compiler.AddResource) for "x-discriminator" objects of
+// the form {"propertyName": ..., "mapping": {value: ref, ...}}, and
+// registers each one found with RegisterDiscriminator.
+func (c *Compiler) RegisterDiscriminatorAnnotations(schemaURL string, doc any) error {
+	return scanDiscriminatorAnnotations(c, schemaURL, "", doc, xDiscriminatorKeyword)
+}
+
+// RegisterOpenAPIDiscriminators is the OpenAPI-dialect counterpart of
+// RegisterDiscriminatorAnnotations: it scans doc for the plain
+// "discriminator" keyword instead of the vendor-extension
+// "x-discriminator" spelling.
+func (c *Compiler) RegisterOpenAPIDiscriminators(schemaURL string, doc any) error {
+	return scanDiscriminatorAnnotations(c, schemaURL, "", doc, "discriminator")
+}
+
+func scanDiscriminatorAnnotations(c *Compiler, schemaURL, ptr string, v any, keyword string) error {
+	switch v := v.(type) {
+	case map[string]any:
+		if raw, ok := v[keyword]; ok {
+			if err := registerOneDiscriminatorAnnotation(c, schemaURL, ptr, raw); err != nil {
+				return err
+			}
+		}
+		for kw, pv := range v {
+			if kw == keyword {
+				continue
+			}
+			if err := scanDiscriminatorAnnotations(c, schemaURL, ptr+"/"+kw, pv, keyword); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if err := scanDiscriminatorAnnotations(c, schemaURL, fmt.Sprintf("%s/%d", ptr, i), item, keyword); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func registerOneDiscriminatorAnnotation(c *Compiler, schemaURL, ptr string, raw any) error {
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s%s: discriminator must be an object", schemaURL, ptr)
+	}
+	propertyName, _ := obj["propertyName"].(string)
+	if propertyName == "" {
+		return fmt.Errorf("%s%s: discriminator.propertyName must be a non-empty string", schemaURL, ptr)
+	}
+	rawMapping, _ := obj["mapping"].(map[string]any)
+	mapping := make(map[string]string, len(rawMapping))
+	for value, ref := range rawMapping {
+		refStr, ok := ref.(string)
+		if !ok {
+			return fmt.Errorf("%s%s: discriminator.mapping[%q] must be a string", schemaURL, ptr, value)
+		}
+		mapping[value] = refStr
+	}
+	// ptr points at the object carrying the discriminator (the
+	// oneOf/anyOf owner), not at "discriminator" itself.
+	ownerURL := schemaURL
+	if ptr != "" {
+		ownerURL = schemaURL + "#" + ptr
+	}
+	return c.RegisterDiscriminator(ownerURL, propertyName, mapping, DiscriminatorOptions{})
+}
@@ -0,0 +1,118 @@
+package jsonschema_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// buildUnionSchema returns a oneOf schema with n branches, each
+// discriminated by a "kind" property with a distinct constant value,
+// mimicking the shape oapi-codegen emits for large OpenAPI unions.
+func buildUnionSchema(n int) (string, map[string]string) {
+	branches := make([]string, n)
+	defs := make([]string, n)
+	mapping := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		kind := fmt.Sprintf("variant%d", i)
+		ref := fmt.Sprintf("#/$defs/%s", kind)
+		branches[i] = fmt.Sprintf(`{"$ref": %q}`, ref)
+		mapping[kind] = ref
+		defs[i] = fmt.Sprintf(`%q: {
+			"type": "object",
+			"properties": {
+				"kind": {"const": %q},
+				"value%d": {"type": "integer"}
+			},
+			"required": ["kind"]
+		}`, kind, kind, i)
+	}
+
+	// "required"/"properties" on the owning object, alongside oneOf --
+	// the sibling constraints a discriminator's fast path must still
+	// apply, exactly as the OpenAPI discriminator object expects of
+	// whatever schema it decorates.
+	schema := fmt.Sprintf(`{
+		"type": "object",
+		"required": ["kind"],
+		"properties": {"kind": {"type": "string"}},
+		"oneOf": [%s],
+		"$defs": {%s}
+	}`, strings.Join(branches, ","), strings.Join(defs, ","))
+	return schema, mapping
+}
+
+// unionSchemaURL is the object that carries "oneOf" (and the sibling
+// "required"/"properties" above), not the bare oneOf array -- that's
+// what RegisterDiscriminator's fallback compiles and what a miss falls
+// back to validating in full.
+const unionSchemaURL = "union.json"
+
+func compileUnion(b *testing.B, n int, withDiscriminator bool) (*jsonschema.Compiler, any) {
+	b.Helper()
+	schemaSrc, mapping := buildUnionSchema(n)
+	schema, err := jsonschema.UnmarshalJSON(strings.NewReader(schemaSrc))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("union.json", schema); err != nil {
+		b.Fatal(err)
+	}
+	if withDiscriminator {
+		err := c.RegisterDiscriminator(unionSchemaURL, "kind", mapping, jsonschema.DiscriminatorOptions{})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	if _, err := c.Compile("union.json"); err != nil {
+		b.Fatal(err)
+	}
+
+	// The instance matches the last branch: the worst case for a
+	// linear oneOf scan, since every earlier branch must fail first.
+	inst, err := jsonschema.UnmarshalJSON(strings.NewReader(
+		fmt.Sprintf(`{"kind": "variant%d", "value%d": 1}`, n-1, n-1)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return c, inst
+}
+
+func BenchmarkOneOfNoDiscriminator(b *testing.B) {
+	c, inst := compileUnion(b, 20, false)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.ValidateDiscriminated(unionSchemaURL, inst)
+	}
+}
+
+func BenchmarkOneOfWithDiscriminator(b *testing.B) {
+	c, inst := compileUnion(b, 20, true)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.ValidateDiscriminated(unionSchemaURL, inst)
+	}
+}
+
+// BenchmarkOneOfWithDiscriminatorMiss uses a "kind" value absent from
+// the mapping, so every iteration actually falls back to the full
+// O(N) oneOf scan instead of only ever hitting the mapped branch --
+// so the fallback path this package claims to compare against is
+// itself exercised; the result is not checked (DiscriminatorFallback is the zero
+// value of DiscriminatorOptions, so a miss here still validates, it
+// just can't match any branch).
+func BenchmarkOneOfWithDiscriminatorMiss(b *testing.B) {
+	c, _ := compileUnion(b, 20, true)
+	inst, err := jsonschema.UnmarshalJSON(strings.NewReader(`{"kind": "unknown-variant"}`))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.ValidateDiscriminated(unionSchemaURL, inst)
+	}
+}
@@ -55,14 +55,22 @@ func (ss SubSchemas) collect(obj map[string]any, ptr jsonPointer, target map[jso
 }
 
 type Draft struct {
-	version       int
-	url           string
-	sch           *Schema
-	id            string             // property name used to represent id
-	subschemas    SubSchemas         // locations of subschemas
-	vocabPrefix   string             // prefix used for vocabulary
-	allVocabs     map[string]*Schema // names of supported vocabs with its schemas
-	defaultVocabs []string           // names of default vocabs
+	version         int
+	url             string
+	sch             *Schema
+	id              string             // property name used to represent id
+	subschemas      SubSchemas         // locations of subschemas
+	vocabPrefix     string             // prefix used for vocabulary
+	allVocabs       map[string]*Schema // names of supported vocabs with its schemas
+	defaultVocabs   []string           // names of default vocabs
+	annotationOnly  []string           // keywords PrepareOpenAPISchema strips before compiling, never an "unknown keyword"
+	rewriteNullable bool               // whether PrepareOpenAPISchema rewrites "nullable: true" into a "type" union (OpenAPI 3.0 only)
+}
+
+// isAnnotationOnly reports whether kw is a keyword this draft accepts
+// and ignores, rather than rejecting as an unknown keyword.
+func (d *Draft) isAnnotationOnly(kw string) bool {
+	return slices.Contains(d.annotationOnly, kw)
 }
 
 func (d *Draft) String() string {
@@ -256,7 +264,14 @@ func (d *Draft) getVocabs(url url, doc any) ([]string, error) {
 	return vocabs, nil
 }
 
+// validate runs v (the schema document itself) through d's meta-schema.
+// d.sch is nil for dialects that don't ship one (OpenAPI30, OpenAPI31
+// -- see their doc comment), in which case the meta-schema check is
+// skipped rather than dereferencing a nil *Schema.
 func (d *Draft) validate(up urlPtr, v any, regexpEngine RegexpEngine) error {
+	if d.sch == nil {
+		return nil
+	}
 	err := d.sch.validate(v, regexpEngine)
 	if err != nil {
 		return &SchemaValidationError{URL: up.String(), Err: err}
@@ -3,6 +3,7 @@ package jsonschema_test
 import (
 	"encoding/hex"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -129,6 +130,51 @@ func Example_fromHTTPS() {
 	// valid: true
 }
 
+// Example_fromYAML shows how to validate a YAML instance against a
+// schema using UnmarshalYAML instead of UnmarshalJSON.
+func Example_fromYAML() {
+	schema, err := jsonschema.UnmarshalJSON(strings.NewReader(`{
+        "type": "object",
+        "properties": {
+            "name": { "type": "string" },
+            "replicas": { "type": "integer" }
+        },
+        "required": ["name"]
+    }`))
+	if err != nil {
+		log.Fatal(err)
+	}
+	inst, err := jsonschema.UnmarshalYAML(strings.NewReader("name: web\nreplicas: 3\n"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("schema.json", schema); err != nil {
+		log.Fatal(err)
+	}
+	sch, err := c.Compile("schema.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = sch.Validate(inst)
+	fmt.Println("valid:", err == nil)
+	// Output:
+	// valid: true
+}
+
+// Example_fromYAMLNonStringKey confirms UnmarshalYAML reports a
+// *NonStringKeyError, rather than silently stringifying the key or
+// surfacing a raw decode error, for a mapping key that isn't a
+// string.
+func Example_fromYAMLNonStringKey() {
+	_, err := jsonschema.UnmarshalYAML(strings.NewReader("1: a\n"))
+	var keyErr *jsonschema.NonStringKeyError
+	fmt.Println(errors.As(err, &keyErr))
+	// Output:
+	// true
+}
+
 func Example_customFormat() {
 	validatePalindrome := func(v any) error {
 		s, ok := v.(string)
@@ -231,6 +277,81 @@ func Example_customContentMediaType() {
 	// valid: false
 }
 
+// Example_commonFormats shows how to opt into the formats bundled
+// with CommonFormats instead of writing a custom Format.
+func Example_commonFormats() {
+	schema, err := jsonschema.UnmarshalJSON(strings.NewReader(`{
+        "type": "object",
+        "properties": {
+            "version": { "type": "string", "format": "semver" }
+        }
+    }`))
+	if err != nil {
+		log.Fatal(err)
+	}
+	inst, err := jsonschema.UnmarshalJSON(strings.NewReader(`{"version": "not-a-version"}`))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c := jsonschema.NewCompiler()
+	for _, f := range jsonschema.CommonFormats() {
+		c.RegisterFormat(f)
+	}
+	c.AssertFormat()
+	if err := c.AddResource("schema.json", schema); err != nil {
+		log.Fatal(err)
+	}
+	sch, err := c.Compile("schema.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = sch.Validate(inst)
+	fmt.Println("valid:", err == nil)
+	// Output:
+	// valid: false
+}
+
+// Example_validateOutput shows how to get the standardized
+// draft 2019-09 output structure instead of a Go error tree.
+func Example_validateOutput() {
+	schema, err := jsonschema.UnmarshalJSON(strings.NewReader(`{
+        "type": "object",
+        "properties": {
+            "speak": { "const": "meow" }
+        },
+        "required": ["speak"]
+    }`))
+	if err != nil {
+		log.Fatal(err)
+	}
+	inst, err := jsonschema.UnmarshalJSON(strings.NewReader(`{"speak": "woof"}`))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("schema.json", schema); err != nil {
+		log.Fatal(err)
+	}
+	sch, err := c.Compile("schema.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, format := range []jsonschema.OutputFormat{
+		jsonschema.OutputFlag,
+		jsonschema.OutputBasic,
+		jsonschema.OutputDetailed,
+	} {
+		unit := sch.ValidateOutput(inst, format)
+		fmt.Println("valid:", unit.Valid)
+	}
+	// Output:
+	// valid: false
+	// valid: false
+	// valid: false
+}
+
 type dclarkRegexp regexp2.Regexp
 
 func (re *dclarkRegexp) MatchString(s string) bool {
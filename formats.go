@@ -0,0 +1,225 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommonFormats returns ready-to-register Format values for the
+// handful of formats Compose CLIs, Kubernetes tools, and CI linters
+// built on this module keep reimplementing from scratch: "duration",
+// "port", "ports", "semver", "cron", "byte-size", "uuid", "uuid3",
+// "uuid4" and "uuid5". Register the ones a schema actually uses:
+//
+//	for _, f := range jsonschema.CommonFormats() {
+//		c.RegisterFormat(f)
+//	}
+func CommonFormats() []*Format {
+	return []*Format{
+		durationFormat,
+		portFormat,
+		portsFormat,
+		semverFormat,
+		cronFormat,
+		byteSizeFormat,
+		uuidFormat,
+		uuid3Format,
+		uuid4Format,
+		uuid5Format,
+	}
+}
+
+// durationFormat validates "duration" using Go's time.ParseDuration
+// grammar ("1h30m", "500ms"), distinct from the RFC 3339 "PnYnMnD"
+// duration some other JSON Schema implementations check under the
+// same name.
+var durationFormat = &Format{
+	Name: "duration",
+	Validate: func(v any) error {
+		s, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		_, err := time.ParseDuration(s)
+		return err
+	},
+}
+
+func parsePort(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid port number", s)
+	}
+	if n < 1 || n > 65535 {
+		return 0, fmt.Errorf("port %d out of range 1-65535", n)
+	}
+	return n, nil
+}
+
+// portFormat validates "port" as a single TCP/UDP port number, 1-65535.
+var portFormat = &Format{
+	Name: "port",
+	Validate: func(v any) error {
+		s, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		_, err := parsePort(s)
+		return err
+	},
+}
+
+var portRangeRe = regexp.MustCompile(`^[0-9]+(-[0-9]+)?$`)
+
+func parsePortRange(s string) error {
+	if !portRangeRe.MatchString(s) {
+		return fmt.Errorf("%q is not a valid port or port range", s)
+	}
+	for _, part := range strings.SplitN(s, "-", 2) {
+		if _, err := parsePort(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// portsFormat validates "ports" as a single port, a port range
+// ("8080-8090"), or a Docker Compose style mapping with an optional
+// published side and protocol ("8080:80", "8080-8090:80-90/tcp").
+var portsFormat = &Format{
+	Name: "ports",
+	Validate: func(v any) error {
+		s, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		spec, protocol, hasProtocol := strings.Cut(s, "/")
+		if hasProtocol && protocol != "tcp" && protocol != "udp" {
+			return fmt.Errorf("%q is not a valid protocol, want tcp or udp", protocol)
+		}
+		published, target, hasPublished := strings.Cut(spec, ":")
+		if hasPublished {
+			if err := parsePortRange(published); err != nil {
+				return err
+			}
+			return parsePortRange(target)
+		}
+		return parsePortRange(spec)
+	},
+}
+
+// semverRe is the grammar published at semver.org for Semantic
+// Versioning 2.0.0, without a leading "v".
+var semverRe = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+var semverFormat = &Format{
+	Name: "semver",
+	Validate: func(v any) error {
+		s, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		if !semverRe.MatchString(s) {
+			return fmt.Errorf("%q is not a valid semantic version", s)
+		}
+		return nil
+	},
+}
+
+// cronFieldRe matches a single cron field: "*", a number, a range, a
+// step, or a comma separated list of those. Month and day-of-week
+// names ("jan", "mon") are also accepted, since crontab(5) allows them.
+var cronFieldRe = regexp.MustCompile(`^(\*|[0-9A-Za-z]+(-[0-9A-Za-z]+)?)(/[0-9]+)?(,(\*|[0-9A-Za-z]+(-[0-9A-Za-z]+)?)(/[0-9]+)?)*$`)
+
+// cronFormat validates "cron" as a 5-field (minute hour dom month
+// dow) or 6-field (with a leading seconds or trailing year field)
+// whitespace-separated expression.
+var cronFormat = &Format{
+	Name: "cron",
+	Validate: func(v any) error {
+		s, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		fields := strings.Fields(s)
+		if len(fields) != 5 && len(fields) != 6 {
+			return fmt.Errorf("cron expression %q must have 5 or 6 fields, got %d", s, len(fields))
+		}
+		for _, f := range fields {
+			if !cronFieldRe.MatchString(f) {
+				return fmt.Errorf("invalid cron field %q in %q", f, s)
+			}
+		}
+		return nil
+	},
+}
+
+// byteSizeRe is the grammar used by Kubernetes' resource.Quantity: a
+// decimal number followed by an optional binary suffix ("Ki", "Mi",
+// "Gi", "Ti", "Pi", "Ei"), decimal suffix ("m", "k", "M", "G", "T",
+// "P", "E" -- lowercase "k", unlike "K" or "Ki"), or decimal exponent
+// ("e"/"E" followed by a signed integer), e.g. "1Gi", "512M", "1.5Ki",
+// "100m", "1e3". A suffix and an exponent don't combine.
+var byteSizeRe = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?([eE][+-]?[0-9]+|Ki|Mi|Gi|Ti|Pi|Ei|[kMGTPE]|m)?$`)
+
+var byteSizeFormat = &Format{
+	Name: "byte-size",
+	Validate: func(v any) error {
+		s, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		if !byteSizeRe.MatchString(s) {
+			return fmt.Errorf("%q is not a valid byte size", s)
+		}
+		return nil
+	},
+}
+
+// uuidAnyVersionRe matches a UUID of any RFC 4122 version.
+var uuidAnyVersionRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[1-5][0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`)
+
+var uuidFormat = &Format{
+	Name: "uuid",
+	Validate: func(v any) error {
+		s, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		if !uuidAnyVersionRe.MatchString(s) {
+			return fmt.Errorf("%q is not a valid uuid", s)
+		}
+		return nil
+	},
+}
+
+// uuidVersionFormat returns a Format that additionally pins the UUID
+// version nibble, for "uuid3", "uuid4" and "uuid5".
+func uuidVersionFormat(name string, version byte) *Format {
+	re := regexp.MustCompile(fmt.Sprintf(
+		`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-%c[0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`, version))
+	return &Format{
+		Name: name,
+		Validate: func(v any) error {
+			s, ok := v.(string)
+			if !ok {
+				return nil
+			}
+			if !re.MatchString(s) {
+				return fmt.Errorf("%q is not a valid %s", s, name)
+			}
+			return nil
+		},
+	}
+}
+
+var (
+	uuid3Format = uuidVersionFormat("uuid3", '3')
+	uuid4Format = uuidVersionFormat("uuid4", '4')
+	uuid5Format = uuidVersionFormat("uuid5", '5')
+)
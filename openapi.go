@@ -0,0 +1,134 @@
+package jsonschema
+
+// OpenAPI30 and OpenAPI31 let Compiler compile schemas embedded in
+// OpenAPI 3.0 and 3.1 documents, which lean on a handful of keywords
+// and a "nullable" convention that the plain JSON Schema drafts don't
+// define. Both are 2020-12 supersets, so their version is reported as
+// 2020 -- using a lower sentinel here would wrongly trip the
+// version-gated draft-04 "$ref siblings are ignored" behavior in
+// getID and the "$vocabulary" handling in getVocabs.
+//
+// Unlike the plain drafts, schema documents aren't compiled as-is:
+// run them through PrepareOpenAPISchema first, which rewrites
+// "nullable" and drops the keywords these dialects accept purely as
+// annotations, before handing the result to Compiler.AddResource:
+//
+//	doc = jsonschema.PrepareOpenAPISchema(jsonschema.OpenAPI30, doc)
+//	c.AddResource(url, doc)
+//
+// Neither dialect has a meta-schema of its own (sch is left nil), so
+// unlike the plain drafts, compiling under OpenAPI30 or OpenAPI31
+// skips self-validating the schema document and validates instances
+// only -- see the sch == nil guard in Draft.validate.
+var (
+	OpenAPI30 = &Draft{
+		version: 2020,
+		url:     "https://spec.openapis.org/oas/3.0/dialect/base",
+		id:      "$id",
+		subschemas: joinMaps(Draft2020.subschemas, map[string][]SchemaPosition{
+			"discriminator": {{PosProp, PosProp}},
+		}),
+		vocabPrefix:     "",
+		allVocabs:       map[string]*Schema{},
+		defaultVocabs:   []string{"core", "applicator", "unevaluated", "validation"},
+		rewriteNullable: true,
+		annotationOnly: []string{
+			"xml", "example", "externalDocs", "deprecated",
+		},
+	}
+
+	OpenAPI31 = &Draft{
+		version: 2020,
+		url:     "https://spec.openapis.org/oas/3.1/dialect/base",
+		id:      "$id",
+		subschemas: joinMaps(Draft2020.subschemas, map[string][]SchemaPosition{
+			"discriminator": {{PosProp, PosProp}},
+		}),
+		vocabPrefix: "https://spec.openapis.org/oas/3.1/vocab/",
+		allVocabs: map[string]*Schema{
+			"base": nil,
+		},
+		defaultVocabs: []string{"core", "applicator", "unevaluated", "validation", "base"},
+		annotationOnly: []string{
+			"xml", "example", "externalDocs", "deprecated",
+		},
+	}
+)
+
+// PrepareOpenAPISchema walks every (sub)schema object in doc -- using
+// d.subschemas, the same keyword/position table Compiler itself uses
+// to find subschemas -- and, in place:
+//
+//   - rewrites "nullable: true" into a "type" union, when d.rewriteNullable
+//     (OpenAPI30; 3.1 dropped "nullable" in favor of real type unions)
+//   - deletes any keyword in d.annotationOnly ("xml", "example",
+//     "externalDocs", "deprecated"), so nothing downstream has to
+//     special-case them as "unknown"
+//
+// It does not touch "discriminator" or "x-discriminator": register
+// those with RegisterOpenAPIDiscriminators / RegisterDiscriminatorAnnotations
+// instead, since they need a *Compiler to resolve their mapping refs.
+//
+// Call it before Compiler.AddResource:
+//
+//	doc = jsonschema.PrepareOpenAPISchema(jsonschema.OpenAPI30, doc)
+//	c.AddResource(url, doc)
+func PrepareOpenAPISchema(d *Draft, doc any) any {
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return doc
+	}
+
+	// d.subschemas.collect only finds an object's immediate
+	// subschemas, so walk level by level from the root until every
+	// nested one has been visited.
+	queue := []any{obj}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		obj, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		target := map[jsonPointer]any{}
+		d.subschemas.collect(obj, "", target)
+		for _, sub := range target {
+			queue = append(queue, sub)
+		}
+		if d.rewriteNullable {
+			rewriteOpenAPINullable(obj)
+		}
+		for _, kw := range d.annotationOnly {
+			delete(obj, kw)
+		}
+	}
+	return doc
+}
+
+// rewriteOpenAPINullable rewrites OpenAPI 3.0's "nullable: true" into
+// a "type" union JSON Schema itself understands:
+//
+//	{"type": "string", "nullable": true} -> {"type": ["string", "null"], "nullable": true}
+//
+// It is a no-op when "type" is absent, since there is nothing to
+// widen. PrepareOpenAPISchema calls this for every object-shaped
+// (sub)schema under OpenAPI30.
+func rewriteOpenAPINullable(obj map[string]any) {
+	nullable, _ := obj["nullable"].(bool)
+	if !nullable {
+		return
+	}
+	switch t := obj["type"].(type) {
+	case string:
+		if t != "null" {
+			obj["type"] = []any{t, "null"}
+		}
+	case []any:
+		for _, v := range t {
+			if v == "null" {
+				return
+			}
+		}
+		obj["type"] = append(append([]any{}, t...), "null")
+	}
+}
@@ -0,0 +1,51 @@
+package jsonschema
+
+// OutputFormat selects the shape produced by Schema.ValidateOutput.
+type OutputFormat int
+
+const (
+	// OutputFlag reports only whether the instance is valid.
+	OutputFlag OutputFormat = iota
+	// OutputBasic flattens every error into a single list, per
+	// ValidationError.BasicOutput.
+	OutputBasic
+	// OutputDetailed mirrors the schema's structure, collapsing any
+	// branch that validated successfully, per
+	// ValidationError.DetailedOutput.
+	OutputDetailed
+)
+
+// There is no OutputVerbose: the draft 2019-09 output spec's verbose
+// format keeps the full evaluation tree, including branches that
+// passed, but this package's *ValidationError only ever retains
+// failing branches -- there's no passing-subschema detail to report,
+// so a fourth format here would just be DetailedOutput under another
+// name.
+
+// ValidateOutput validates v the same way Validate does, but returns
+// the result as this package's existing OutputUnit structure (see
+// ValidationError.BasicOutput and ValidationError.DetailedOutput) in
+// the requested format, instead of a Go error tree -- the shape
+// downstream lint/report tools, IDE integrations, and metrics
+// pipelines actually want to consume programmatically.
+func (s *Schema) ValidateOutput(v any, format OutputFormat) *OutputUnit {
+	err := s.Validate(v)
+	if err == nil {
+		return &OutputUnit{Valid: true}
+	}
+	ve, ok := err.(*ValidationError)
+	if !ok {
+		return &OutputUnit{Valid: false}
+	}
+
+	switch format {
+	case OutputFlag:
+		return &OutputUnit{Valid: false}
+	case OutputBasic:
+		u := ve.BasicOutput()
+		return &u
+	default: // OutputDetailed
+		u := ve.DetailedOutput()
+		return &u
+	}
+}
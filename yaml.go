@@ -0,0 +1,118 @@
+package jsonschema
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NonStringKeyError is returned by UnmarshalYAML when a YAML mapping
+// uses a key that is not a string, since JSON Schema requires every
+// property name to be a string.
+type NonStringKeyError struct {
+	Path string
+	Key  any
+}
+
+func (e *NonStringKeyError) Error() string {
+	return fmt.Sprintf("non-string key %v at %s", e.Key, e.Path)
+}
+
+// UnmarshalYAML decodes a single YAML document from r into the same
+// any tree that UnmarshalJSON produces: mappings become map[string]any,
+// sequences become []any, and numeric scalars keep their int/float
+// distinction so that "type" and "multipleOf" behave the same as they
+// do for JSON instances.
+//
+// Decoding goes through yaml.Node rather than decoding straight into
+// an any, because yaml.v3 resolves a generic mapping into
+// map[string]any by requiring every key to decode as a string -- a
+// non-string key (e.g. "1: a") fails there with a raw, untyped decode
+// error instead of the *NonStringKeyError this package's callers need
+// to detect programmatically.
+func UnmarshalYAML(r io.Reader) (any, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return nodeToAny(&doc, "$")
+}
+
+func nodeToAny(n *yaml.Node, path string) (any, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		return nodeToAny(n.Content[0], path)
+	case yaml.AliasNode:
+		return nodeToAny(n.Alias, path)
+	case yaml.MappingNode:
+		m := make(map[string]any, len(n.Content)/2)
+		for i := 0; i < len(n.Content); i += 2 {
+			keyNode, valueNode := n.Content[i], n.Content[i+1]
+			if keyNode.Tag != "!!str" {
+				var key any
+				if err := keyNode.Decode(&key); err != nil {
+					key = keyNode.Value
+				}
+				return nil, &NonStringKeyError{Path: path, Key: key}
+			}
+			v, err := nodeToAny(valueNode, path+"."+keyNode.Value)
+			if err != nil {
+				return nil, err
+			}
+			m[keyNode.Value] = v
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		a := make([]any, len(n.Content))
+		for i, item := range n.Content {
+			v, err := nodeToAny(item, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			a[i] = v
+		}
+		return a, nil
+	default: // yaml.ScalarNode and anything else decode straight through
+		var v any
+		if err := n.Decode(&v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+}
+
+// YAMLFileLoader loads schemas and instances from local *.yaml/*.yml
+// files. It is the YAML counterpart of FileLoader, for use in a
+// SchemeURLLoader alongside it.
+type YAMLFileLoader struct{}
+
+func (l YAMLFileLoader) Load(url string) (any, error) {
+	path := strings.TrimPrefix(url, "file://")
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return UnmarshalYAML(f)
+}
+
+// YAMLMediaType implements contentMediaType "application/yaml". Register
+// it with Compiler.RegisterContentMediaType so that contentSchema can be
+// evaluated against YAML embedded in a string instance.
+var YAMLMediaType = &MediaType{
+	Name: "application/yaml",
+	Validate: func(b []byte) error {
+		_, err := UnmarshalYAML(bytes.NewReader(b))
+		return err
+	},
+	UnmarshalJSON: func(b []byte) (any, error) {
+		return UnmarshalYAML(bytes.NewReader(b))
+	},
+}